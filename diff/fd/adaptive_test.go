@@ -0,0 +1,58 @@
+// Copyright ©2024 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fd
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDerivativeAdaptiveAnalytic(t *testing.T) {
+	cases := []struct {
+		name string
+		f    func(float64) float64
+		df   func(float64) float64
+		x    float64
+	}{
+		{"sin", math.Sin, math.Cos, 0.5},
+		{"exp", math.Exp, math.Exp, 1},
+		{"cube", func(x float64) float64 { return x * x * x }, func(x float64) float64 { return 3 * x * x }, 2},
+	}
+	for _, test := range cases {
+		deriv, errEst, steps := DerivativeAdaptive(test.f, test.x, nil)
+		want := test.df(test.x)
+		if math.Abs(deriv-want) > 1e-9 {
+			t.Errorf("%s: got derivative %v, want %v (errEst %v, steps %d)", test.name, deriv, want, errEst, steps)
+		}
+		if steps < 1 {
+			t.Errorf("%s: got %d steps, want at least 1", test.name, steps)
+		}
+	}
+}
+
+func TestDerivativeAdaptiveMaxDepthPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("DerivativeAdaptive with MaxDepth 1 did not panic")
+		}
+	}()
+	DerivativeAdaptive(math.Sin, 0, &AdaptiveSettings{MaxDepth: 1})
+}
+
+func TestDerivativeAdaptiveNoisyStopsEarly(t *testing.T) {
+	// A high-frequency component riding on top of sin makes the raw
+	// estimates stop improving well before MaxDepth rows are computed,
+	// so the extrapolation should terminate early rather than running
+	// to the full depth.
+	noisy := func(x float64) float64 {
+		return math.Sin(x) + 1e-3*math.Sin(x*1e6)
+	}
+
+	const maxDepth = 8
+	_, _, steps := DerivativeAdaptive(noisy, 0.5, &AdaptiveSettings{MaxDepth: maxDepth})
+	if steps >= maxDepth {
+		t.Errorf("got %d steps against a noisy function, want fewer than MaxDepth (%d)", steps, maxDepth)
+	}
+}
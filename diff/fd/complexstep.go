@@ -0,0 +1,71 @@
+// Copyright ©2024 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fd
+
+// defaultComplexStep is the step size used by ComplexStep and
+// ComplexStepJacobian when the caller passes h == 0. Because the method is
+// free of subtractive cancellation, it remains accurate at step sizes far
+// smaller than those usable by the real-valued formulas in Derivative.
+const defaultComplexStep = 1e-20
+
+// ComplexStep estimates the first derivative of f at x using the
+// complex-step method: imag(f(x+ih))/h. If h is zero, defaultComplexStep is
+// used.
+//
+// Unlike the real-valued stencils used by Derivative, complex-step
+// differentiation involves no subtraction of nearby function values, so it
+// has no truncation/round-off trade-off and remains accurate down to
+// h values far smaller than is useful for a real finite difference. Its use
+// requires f to be holomorphic (complex-differentiable) in a neighborhood of
+// x; f must not use operations such as math.Abs, a real part, or a complex
+// conjugate that are not holomorphic, or the result will be wrong.
+//
+// There is no Formula-based entry point for complex-step, unlike Derivative:
+// a Formula is a stencil of real-valued coefficients and offsets designed to
+// cancel real-arithmetic truncation error, which has no equivalent for a
+// method whose only evaluation is a single complex step with no such error
+// to cancel. ComplexStep and ComplexStepJacobian are this package's
+// complete complex-step API.
+func ComplexStep(f func(complex128) complex128, x float64, h float64) float64 {
+	if h == 0 {
+		h = defaultComplexStep
+	}
+	return imag(f(complex(x, h))) / h
+}
+
+// ComplexStepJacobian computes the Jacobian of f at x using the complex-step
+// method, evaluating one column of the Jacobian per call to f. If h is zero,
+// defaultComplexStep is used.
+//
+// dst is returned with dimensions len(f(x)) by len(x); if dst is nil, a
+// matrix of the correct dimensions is allocated. f must be holomorphic in a
+// neighborhood of x, as for ComplexStep.
+func ComplexStepJacobian(dst [][]float64, f func([]complex128) []complex128, x []float64, h float64) [][]float64 {
+	if h == 0 {
+		h = defaultComplexStep
+	}
+
+	xc := make([]complex128, len(x))
+	for i, v := range x {
+		xc[i] = complex(v, 0)
+	}
+
+	for j := range x {
+		xc[j] = complex(x[j], h)
+		fx := f(xc)
+		if dst == nil {
+			dst = make([][]float64, len(fx))
+			for i := range dst {
+				dst[i] = make([]float64, len(x))
+			}
+		}
+		for i, v := range fx {
+			dst[i][j] = imag(v) / h
+		}
+		xc[j] = complex(x[j], 0)
+	}
+
+	return dst
+}
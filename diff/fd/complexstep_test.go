@@ -0,0 +1,60 @@
+// Copyright ©2024 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fd
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+)
+
+func TestComplexStep(t *testing.T) {
+	cases := []struct {
+		name string
+		f    func(complex128) complex128
+		df   func(float64) float64
+		x    float64
+	}{
+		{"sin", cmplx.Sin, math.Cos, 0.75},
+		{"exp", cmplx.Exp, math.Exp, 1.25},
+		{
+			name: "cube",
+			f:    func(z complex128) complex128 { return z * z * z },
+			df:   func(x float64) float64 { return 3 * x * x },
+			x:    -2,
+		},
+	}
+	for _, test := range cases {
+		got := ComplexStep(test.f, test.x, 0)
+		want := test.df(test.x)
+		if math.Abs(got-want) > 1e-12 {
+			t.Errorf("%s: got %v, want %v", test.name, got, want)
+		}
+	}
+}
+
+func TestComplexStepJacobian(t *testing.T) {
+	// f(x, y) = (x^2*y, x + y^2), with Jacobian
+	// [[2xy, x^2], [1, 2y]].
+	f := func(z []complex128) []complex128 {
+		x, y := z[0], z[1]
+		return []complex128{x * x * y, x + y*y}
+	}
+
+	x := []float64{2, 3}
+	got := ComplexStepJacobian(nil, f, x, 0)
+
+	want := [][]float64{
+		{2 * x[0] * x[1], x[0] * x[0]},
+		{1, 2 * x[1]},
+	}
+	for i := range want {
+		for j := range want[i] {
+			if math.Abs(got[i][j]-want[i][j]) > 1e-9 {
+				t.Errorf("Jacobian[%d][%d] = %v, want %v", i, j, got[i][j], want[i][j])
+			}
+		}
+	}
+}
@@ -0,0 +1,229 @@
+// Copyright ©2024 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package multijson implements marshaling and unmarshaling of multigraphs
+// to and from a flat nodes+lines JSON schema. Unlike the simple-graph
+// encodings in graph/encoding, the schema here carries each line's
+// graph-unique ID so that parallel edges between the same pair of nodes
+// survive a round trip.
+//
+// Encode and Decode both work a node or line at a time: neither builds a
+// struct holding the whole encoded graph before writing or after reading,
+// so memory use is bounded by the graph itself rather than by its JSON
+// representation. Decode buffers decoded lines only long enough to resolve
+// their endpoints once the full document has been read, so "nodes" and
+// "lines" may appear in either order.
+//
+// Scope: only weighted undirected multigraphs are handled here, such as
+// multi.WeightedUndirectedGraph and multi.SynchronizedWeightedUndirectedGraph.
+// Encode takes
+// a graph.WeightedUndirectedMultigraph directly, and nothing in this
+// package constructs a directed or unweighted multigraph, so there is
+// currently no way to Encode or Decode one, even though Decode's Builder
+// interface is narrow enough that such a type could satisfy it.
+package multijson // import "gonum.org/v1/gonum/graph/encoding/multijson"
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+// Node is the JSON representation of a graph node.
+type Node struct {
+	ID int64 `json:"id"`
+}
+
+// Line is the JSON representation of a single line of a multigraph,
+// identified by its own graph-unique ID in addition to its endpoints.
+type Line struct {
+	ID     int64   `json:"id"`
+	From   int64   `json:"from"`
+	To     int64   `json:"to"`
+	Weight float64 `json:"weight"`
+}
+
+// NewNodeFunc returns a graph.Node with the given ID, allowing callers to
+// decode into user-defined, attribute-carrying node types.
+type NewNodeFunc func(id int64) graph.Node
+
+// NewLineFunc returns a graph.WeightedLine with the given ID, endpoints and
+// weight, allowing callers to decode into user-defined line types.
+type NewLineFunc func(id int64, from, to graph.Node, weight float64) graph.WeightedLine
+
+// Builder is the subset of a multi graph's mutators needed to populate it
+// during Decode. multi.WeightedUndirectedGraph and
+// multi.SynchronizedWeightedUndirectedGraph satisfy Builder; see the package
+// doc for the scope of this package.
+type Builder interface {
+	AddNode(graph.Node)
+	SetWeighted(graph.WeightedLine)
+}
+
+// Encode writes g to w as a JSON object of the form
+// {"nodes":[{"id":...},...],"lines":[{"id":...,"from":...,"to":...,"weight":...},...]},
+// writing each node and line to w as it is produced rather than
+// accumulating them into an in-memory document first. Each line is written
+// once, regardless of how many times it is reachable while walking the
+// graph's adjacency.
+func Encode(w io.Writer, g graph.WeightedUndirectedMultigraph) error {
+	if _, err := io.WriteString(w, `{"nodes":[`); err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(w)
+	nodes := g.Nodes()
+	for i, n := range nodes {
+		if i > 0 {
+			if _, err := io.WriteString(w, ","); err != nil {
+				return err
+			}
+		}
+		if err := enc.Encode(Node{ID: n.ID()}); err != nil {
+			return err
+		}
+	}
+
+	if _, err := io.WriteString(w, `],"lines":[`); err != nil {
+		return err
+	}
+
+	seen := make(map[int64]bool)
+	first := true
+	for _, u := range nodes {
+		for _, v := range g.From(u) {
+			for _, l := range g.WeightedLines(u, v) {
+				lid := l.ID()
+				if seen[lid] {
+					continue
+				}
+				seen[lid] = true
+
+				if !first {
+					if _, err := io.WriteString(w, ","); err != nil {
+						return err
+					}
+				}
+				first = false
+				err := enc.Encode(Line{
+					ID:     lid,
+					From:   l.From().ID(),
+					To:     l.To().ID(),
+					Weight: l.Weight(),
+				})
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	_, err := io.WriteString(w, "]}")
+	return err
+}
+
+// Decode reads a multijson-encoded multigraph from r into dst, using
+// newNode and newLine to construct the nodes and lines before they are
+// added to dst. Decode streams the input with a json.Decoder, decoding one
+// node or line at a time rather than reading the whole document into a
+// struct up front. The "nodes" and "lines" arrays may appear in either
+// order: line records are buffered until the top-level object has been
+// fully read, then resolved against the complete id-to-node map, so a line
+// referencing a node declared later in the document still round-trips.
+func Decode(r io.Reader, dst Builder, newNode NewNodeFunc, newLine NewLineFunc) error {
+	dec := json.NewDecoder(r)
+
+	if err := expectDelim(dec, '{'); err != nil {
+		return err
+	}
+
+	nodes := make(map[int64]graph.Node)
+	var pending []Line
+	for dec.More() {
+		key, err := nextKey(dec)
+		if err != nil {
+			return err
+		}
+		if err := expectDelim(dec, '['); err != nil {
+			return err
+		}
+
+		switch key {
+		case "nodes":
+			for dec.More() {
+				var n Node
+				if err := dec.Decode(&n); err != nil {
+					return err
+				}
+				if _, exists := nodes[n.ID]; exists {
+					return fmt.Errorf("multijson: duplicate node %d", n.ID)
+				}
+				node := newNode(n.ID)
+				nodes[n.ID] = node
+				dst.AddNode(node)
+			}
+		case "lines":
+			for dec.More() {
+				var l Line
+				if err := dec.Decode(&l); err != nil {
+					return err
+				}
+				pending = append(pending, l)
+			}
+		default:
+			return fmt.Errorf("multijson: unexpected key %q", key)
+		}
+
+		if err := expectDelim(dec, ']'); err != nil {
+			return err
+		}
+	}
+
+	if err := expectDelim(dec, '}'); err != nil {
+		return err
+	}
+
+	for _, l := range pending {
+		from, ok := nodes[l.From]
+		if !ok {
+			return fmt.Errorf("multijson: line %d has unknown from node %d", l.ID, l.From)
+		}
+		to, ok := nodes[l.To]
+		if !ok {
+			return fmt.Errorf("multijson: line %d has unknown to node %d", l.ID, l.To)
+		}
+		dst.SetWeighted(newLine(l.ID, from, to, l.Weight))
+	}
+
+	return nil
+}
+
+// expectDelim consumes the next JSON token from dec and returns an error if
+// it is not the given delimiter.
+func expectDelim(dec *json.Decoder, want json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if d, ok := tok.(json.Delim); !ok || d != want {
+		return fmt.Errorf("multijson: expected %q, got %v", want, tok)
+	}
+	return nil
+}
+
+// nextKey consumes the next JSON token from dec and returns an error if it
+// is not a string, as is expected of an object key.
+func nextKey(dec *json.Decoder) (string, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return "", err
+	}
+	s, ok := tok.(string)
+	if !ok {
+		return "", fmt.Errorf("multijson: expected object key, got %v", tok)
+	}
+	return s, nil
+}
@@ -0,0 +1,183 @@
+// Copyright ©2024 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package multijson_test
+
+import (
+	"bytes"
+	"sort"
+	"strings"
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/encoding/multijson"
+	"gonum.org/v1/gonum/graph/multi"
+)
+
+// testNode and testLine are minimal graph.Node/graph.WeightedLine
+// implementations used to exercise multijson's pluggable node/line
+// factories without depending on multi's own concrete node and line types.
+type testNode int64
+
+func (n testNode) ID() int64 { return int64(n) }
+
+type testLine struct {
+	id     int64
+	f, t   graph.Node
+	weight float64
+}
+
+func (l testLine) ID() int64        { return l.id }
+func (l testLine) From() graph.Node { return l.f }
+func (l testLine) To() graph.Node   { return l.t }
+func (l testLine) Weight() float64  { return l.weight }
+func (l testLine) ReversedLine() graph.Line {
+	return testLine{id: l.id, f: l.t, t: l.f, weight: l.weight}
+}
+
+func newTestGraph() *multi.WeightedUndirectedGraph {
+	g := multi.NewWeightedUndirectedGraph()
+	g.AddNode(testNode(1))
+	g.AddNode(testNode(2))
+	g.AddNode(testNode(3))
+	g.SetWeighted(testLine{id: 10, f: testNode(1), t: testNode(2), weight: 0.5})
+	g.SetWeighted(testLine{id: 11, f: testNode(1), t: testNode(2), weight: 1.5})
+	g.SetWeighted(testLine{id: 12, f: testNode(2), t: testNode(3), weight: 2})
+	return g
+}
+
+func TestEncodeDecodeRoundTrip(t *testing.T) {
+	g := newTestGraph()
+
+	var buf bytes.Buffer
+	if err := multijson.Encode(&buf, g); err != nil {
+		t.Fatalf("Encode returned error: %v", err)
+	}
+
+	got := multi.NewWeightedUndirectedGraph()
+	newNode := func(id int64) graph.Node { return testNode(id) }
+	newLine := func(id int64, from, to graph.Node, w float64) graph.WeightedLine {
+		return testLine{id: id, f: from, t: to, weight: w}
+	}
+	if err := multijson.Decode(&buf, got, newNode, newLine); err != nil {
+		t.Fatalf("Decode returned error: %v", err)
+	}
+
+	wantNodes := nodeIDs(g.Nodes())
+	gotNodes := nodeIDs(got.Nodes())
+	if !equalInts(wantNodes, gotNodes) {
+		t.Errorf("node IDs do not match after round trip: got %v, want %v", gotNodes, wantNodes)
+	}
+
+	wantLines := lineSpecs(g)
+	gotLines := lineSpecs(got)
+	if !equalLineSpecs(wantLines, gotLines) {
+		t.Errorf("lines do not match after round trip: got %v, want %v", gotLines, wantLines)
+	}
+}
+
+func TestDecodeUnknownNode(t *testing.T) {
+	r := strings.NewReader(`{"nodes":[{"id":1}],"lines":[{"id":10,"from":1,"to":2,"weight":1}]}`)
+	g := multi.NewWeightedUndirectedGraph()
+	newNode := func(id int64) graph.Node { return testNode(id) }
+	newLine := func(id int64, from, to graph.Node, w float64) graph.WeightedLine {
+		return testLine{id: id, f: from, t: to, weight: w}
+	}
+	err := multijson.Decode(r, g, newNode, newLine)
+	if err == nil {
+		t.Fatal("Decode did not return an error for a line referencing an unknown node")
+	}
+}
+
+func TestDecodeDuplicateNode(t *testing.T) {
+	r := strings.NewReader(`{"nodes":[{"id":1},{"id":1}],"lines":[]}`)
+	g := multi.NewWeightedUndirectedGraph()
+	newNode := func(id int64) graph.Node { return testNode(id) }
+	newLine := func(id int64, from, to graph.Node, w float64) graph.WeightedLine {
+		return testLine{id: id, f: from, t: to, weight: w}
+	}
+	err := multijson.Decode(r, g, newNode, newLine)
+	if err == nil {
+		t.Fatal("Decode did not return an error for duplicate node IDs")
+	}
+}
+
+func TestDecodeLinesBeforeNodes(t *testing.T) {
+	r := strings.NewReader(`{"lines":[{"id":10,"from":1,"to":2,"weight":0.5}],"nodes":[{"id":1},{"id":2}]}`)
+	g := multi.NewWeightedUndirectedGraph()
+	newNode := func(id int64) graph.Node { return testNode(id) }
+	newLine := func(id int64, from, to graph.Node, w float64) graph.WeightedLine {
+		return testLine{id: id, f: from, t: to, weight: w}
+	}
+	if err := multijson.Decode(r, g, newNode, newLine); err != nil {
+		t.Fatalf("Decode returned error for a document with lines before nodes: %v", err)
+	}
+
+	wantLines := []lineSpec{{id: 10, from: 1, to: 2, weight: 0.5}}
+	gotLines := lineSpecs(g)
+	if !equalLineSpecs(wantLines, gotLines) {
+		t.Errorf("lines do not match after decoding lines-before-nodes document: got %v, want %v", gotLines, wantLines)
+	}
+}
+
+type lineSpec struct {
+	id       int64
+	from, to int64
+	weight   float64
+}
+
+func lineSpecs(g *multi.WeightedUndirectedGraph) []lineSpec {
+	seen := make(map[int64]bool)
+	var specs []lineSpec
+	for _, u := range g.Nodes() {
+		for _, v := range g.From(u) {
+			for _, l := range g.WeightedLines(u, v) {
+				if seen[l.ID()] {
+					continue
+				}
+				seen[l.ID()] = true
+				from, to := l.From().ID(), l.To().ID()
+				if from > to {
+					from, to = to, from
+				}
+				specs = append(specs, lineSpec{id: l.ID(), from: from, to: to, weight: l.Weight()})
+			}
+		}
+	}
+	sort.Slice(specs, func(i, j int) bool { return specs[i].id < specs[j].id })
+	return specs
+}
+
+func equalLineSpecs(a, b []lineSpec) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func nodeIDs(nodes []graph.Node) []int64 {
+	ids := make([]int64, len(nodes))
+	for i, n := range nodes {
+		ids[i] = n.ID()
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+func equalInts(a, b []int64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
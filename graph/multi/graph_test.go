@@ -0,0 +1,40 @@
+// Copyright ©2024 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package multi
+
+import "gonum.org/v1/gonum/graph"
+
+// testNode and testLine are minimal graph.Node/graph.WeightedLine
+// implementations shared by this package's test files.
+type testNode int64
+
+func (n testNode) ID() int64 { return int64(n) }
+
+type testLine struct {
+	id     int64
+	f, t   graph.Node
+	weight float64
+}
+
+func (l testLine) ID() int64        { return l.id }
+func (l testLine) From() graph.Node { return l.f }
+func (l testLine) To() graph.Node   { return l.t }
+func (l testLine) Weight() float64  { return l.weight }
+func (l testLine) ReversedLine() graph.Line {
+	return testLine{id: l.id, f: l.t, t: l.f, weight: l.weight}
+}
+
+// newTestGraph returns a small WeightedUndirectedGraph with parallel edges
+// between nodes 1 and 2, shared by this package's tests.
+func newTestGraph() *WeightedUndirectedGraph {
+	g := NewWeightedUndirectedGraph()
+	g.AddNode(testNode(1))
+	g.AddNode(testNode(2))
+	g.AddNode(testNode(3))
+	g.SetWeighted(testLine{id: 10, f: testNode(1), t: testNode(2), weight: 0.5})
+	g.SetWeighted(testLine{id: 11, f: testNode(1), t: testNode(2), weight: 1.5})
+	g.SetWeighted(testLine{id: 12, f: testNode(2), t: testNode(3), weight: 2})
+	return g
+}
@@ -0,0 +1,205 @@
+// Copyright ©2024 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package multi
+
+import (
+	"reflect"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+// NodeIter is a stateful iterator over a set of nodes.
+type NodeIter interface {
+	// Next advances the iterator and reports whether a further call to
+	// Node will return a valid node.
+	Next() bool
+	// Node returns the current node.
+	Node() graph.Node
+}
+
+// EdgeIter is a stateful iterator over a set of edges.
+type EdgeIter interface {
+	Next() bool
+	Edge() graph.Edge
+}
+
+// LineIter is a stateful iterator over a set of lines.
+type LineIter interface {
+	Next() bool
+	Line() graph.Line
+}
+
+// nodeIterator is a NodeIter that walks g.nodes directly via a
+// reflect.MapIter, one entry per call to Next, rather than copying the map
+// into a []graph.Node up front.
+type nodeIterator struct {
+	it  *reflect.MapIter
+	cur graph.Node
+}
+
+func newNodeIterator(nodes map[int64]graph.Node) *nodeIterator {
+	if len(nodes) == 0 {
+		return &nodeIterator{}
+	}
+	return &nodeIterator{it: reflect.ValueOf(nodes).MapRange()}
+}
+
+func (it *nodeIterator) Next() bool {
+	if it.it == nil || !it.it.Next() {
+		it.cur = nil
+		return false
+	}
+	it.cur = it.it.Value().Interface().(graph.Node)
+	return true
+}
+
+func (it *nodeIterator) Node() graph.Node {
+	return it.cur
+}
+
+// fromIterator is a NodeIter over the nodes adjacent to some node n, walking
+// the map of n's lines directly via a reflect.MapIter rather than building
+// the []graph.Node of neighbours up front.
+type fromIterator struct {
+	nodes map[int64]graph.Node
+	it    *reflect.MapIter
+	cur   graph.Node
+}
+
+func newFromIterator(adj map[int64]map[int64]graph.WeightedLine, nodes map[int64]graph.Node) *fromIterator {
+	if len(adj) == 0 {
+		return &fromIterator{}
+	}
+	return &fromIterator{nodes: nodes, it: reflect.ValueOf(adj).MapRange()}
+}
+
+func (it *fromIterator) Next() bool {
+	if it.it == nil || !it.it.Next() {
+		it.cur = nil
+		return false
+	}
+	it.cur = it.nodes[it.it.Key().Interface().(int64)]
+	return true
+}
+
+func (it *fromIterator) Node() graph.Node {
+	return it.cur
+}
+
+// edgeIterator is an EdgeIter over the distinct multi.Edge values of a
+// graph, one per (from, to) node pair. The outer set of from-node IDs, and
+// the inner set of to-node IDs for whichever from-node is currently being
+// visited, are each collected up front - at most O(|V|) and O(degree)
+// respectively - but the lines making up an Edge are only gathered, and
+// deduplicated against the reverse (to, from) entry already seen, when Next
+// reaches that (from, to) pair, so a caller that stops calling Next early
+// never gathers the lines for the pairs it didn't reach.
+type edgeIterator struct {
+	lines map[int64]map[int64]map[int64]graph.WeightedLine
+
+	outer []int64 // from-node IDs not yet visited.
+	fid   int64
+	inner []int64 // to-node IDs, for fid, not yet visited.
+
+	seen map[int64]bool
+
+	cur graph.Edge
+}
+
+func (it *edgeIterator) Next() bool {
+	for {
+		for len(it.inner) == 0 {
+			if len(it.outer) == 0 {
+				it.cur = nil
+				return false
+			}
+			it.fid = it.outer[0]
+			it.outer = it.outer[1:]
+			it.inner = make([]int64, 0, len(it.lines[it.fid]))
+			for tid := range it.lines[it.fid] {
+				it.inner = append(it.inner, tid)
+			}
+		}
+
+		tid := it.inner[0]
+		it.inner = it.inner[1:]
+
+		var lines Edge
+		for _, l := range it.lines[it.fid][tid] {
+			lid := l.ID()
+			if it.seen[lid] {
+				continue
+			}
+			it.seen[lid] = true
+			lines = append(lines, l)
+		}
+		if len(lines) != 0 {
+			it.cur = lines
+			return true
+		}
+	}
+}
+
+func (it *edgeIterator) Edge() graph.Edge {
+	return it.cur
+}
+
+// lineIterator is a LineIter over the lines between a pair of nodes,
+// walking the underlying map of lines directly via a reflect.MapIter. The
+// map is already keyed by line ID, so no additional deduplication is
+// needed.
+type lineIterator struct {
+	it  *reflect.MapIter
+	cur graph.Line
+}
+
+func newLineIterator(edge map[int64]graph.WeightedLine) *lineIterator {
+	if len(edge) == 0 {
+		return &lineIterator{}
+	}
+	return &lineIterator{it: reflect.ValueOf(edge).MapRange()}
+}
+
+func (it *lineIterator) Next() bool {
+	if it.it == nil || !it.it.Next() {
+		it.cur = nil
+		return false
+	}
+	it.cur = it.it.Value().Interface().(graph.WeightedLine)
+	return true
+}
+
+func (it *lineIterator) Line() graph.Line {
+	return it.cur
+}
+
+// NodesIter returns an iterator over all the nodes in the graph.
+func (g *WeightedUndirectedGraph) NodesIter() NodeIter {
+	return newNodeIterator(g.nodes)
+}
+
+// EdgesIter returns an iterator over all the edges in the graph. Each edge
+// produced is a multi.Edge.
+func (g *WeightedUndirectedGraph) EdgesIter() EdgeIter {
+	outer := make([]int64, 0, len(g.lines))
+	for fid := range g.lines {
+		outer = append(outer, fid)
+	}
+	return &edgeIterator{lines: g.lines, outer: outer, seen: make(map[int64]bool)}
+}
+
+// FromIter returns an iterator over the nodes in g that can be reached
+// directly from n.
+func (g *WeightedUndirectedGraph) FromIter(n graph.Node) NodeIter {
+	if !g.Has(n) {
+		return &fromIterator{}
+	}
+	return newFromIterator(g.lines[n.ID()], g.nodes)
+}
+
+// LinesBetweenIter returns an iterator over the lines between nodes x and y.
+func (g *WeightedUndirectedGraph) LinesBetweenIter(x, y graph.Node) LineIter {
+	return newLineIterator(g.lines[x.ID()][y.ID()])
+}
@@ -0,0 +1,158 @@
+// Copyright ©2024 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package multi
+
+import (
+	"sort"
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+func TestNodesIterMatchesNodes(t *testing.T) {
+	g := newTestGraph()
+
+	want := idsOf(g.Nodes())
+	var got []int64
+	it := g.NodesIter()
+	for it.Next() {
+		got = append(got, it.Node().ID())
+	}
+	sort.Slice(got, func(i, j int) bool { return got[i] < got[j] })
+
+	if !equalInt64s(want, got) {
+		t.Errorf("NodesIter does not match Nodes: got %v, want %v", got, want)
+	}
+}
+
+func TestFromIterMatchesFrom(t *testing.T) {
+	g := newTestGraph()
+
+	want := idsOf(g.From(testNode(1)))
+	var got []int64
+	it := g.FromIter(testNode(1))
+	for it.Next() {
+		got = append(got, it.Node().ID())
+	}
+	sort.Slice(got, func(i, j int) bool { return got[i] < got[j] })
+
+	if !equalInt64s(want, got) {
+		t.Errorf("FromIter does not match From: got %v, want %v", got, want)
+	}
+
+	if it := g.FromIter(testNode(99)); it.Next() {
+		t.Error("FromIter on an absent node produced a node")
+	}
+}
+
+func TestLinesBetweenIterMatchesLinesBetween(t *testing.T) {
+	g := newTestGraph()
+
+	want := lineIDsOf(g.LinesBetween(testNode(1), testNode(2)))
+	var got []int64
+	it := g.LinesBetweenIter(testNode(1), testNode(2))
+	for it.Next() {
+		got = append(got, it.Line().ID())
+	}
+	sort.Slice(got, func(i, j int) bool { return got[i] < got[j] })
+
+	if !equalInt64s(want, got) {
+		t.Errorf("LinesBetweenIter does not match LinesBetween: got %v, want %v", got, want)
+	}
+}
+
+func TestEdgesIterMatchesEdges(t *testing.T) {
+	g := newTestGraph()
+
+	wantEdges := g.Edges()
+	var want int
+	for _, e := range wantEdges {
+		want += len(e.(Edge))
+	}
+
+	var got int
+	it := g.EdgesIter()
+	for it.Next() {
+		got += len(it.Edge().(Edge))
+	}
+
+	if got != want {
+		t.Errorf("EdgesIter produced %d total lines, want %d", got, want)
+	}
+}
+
+// TestEdgesIterGroupsByNodePair guards against regressing to grouping all
+// of a from-node's lines into one Edge regardless of which neighbor they
+// go to. newTestGraph's node 2 has degree 3 across two distinct
+// neighbors (1 and 3), so a from-node that isn't split by to-node would
+// merge the 1-2 and 2-3 lines into a single, corrupted Edge.
+func TestEdgesIterGroupsByNodePair(t *testing.T) {
+	g := newTestGraph()
+
+	edges := g.Edges()
+	if len(edges) != 2 {
+		t.Fatalf("got %d edges, want 2", len(edges))
+	}
+
+	counts := make(map[[2]int64]int)
+	for _, e := range edges {
+		lines := e.(Edge)
+		if len(lines) == 0 {
+			t.Fatal("got an edge with no lines")
+		}
+		from, to := orderedPair(lines[0].From().ID(), lines[0].To().ID())
+		for _, l := range lines {
+			f, tt := orderedPair(l.From().ID(), l.To().ID())
+			if f != from || tt != to {
+				t.Errorf("edge contains lines from more than one node pair: got (%d,%d) and (%d,%d)", from, to, f, tt)
+			}
+		}
+		counts[[2]int64{from, to}] = len(lines)
+	}
+
+	want := map[[2]int64]int{{1, 2}: 2, {2, 3}: 1}
+	for pair, n := range want {
+		if counts[pair] != n {
+			t.Errorf("edge %v has %d lines, want %d", pair, counts[pair], n)
+		}
+	}
+}
+
+func orderedPair(a, b int64) (int64, int64) {
+	if a > b {
+		return b, a
+	}
+	return a, b
+}
+
+func idsOf(nodes []graph.Node) []int64 {
+	ids := make([]int64, len(nodes))
+	for i, n := range nodes {
+		ids[i] = n.ID()
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+func lineIDsOf(lines []graph.Line) []int64 {
+	ids := make([]int64, len(lines))
+	for i, l := range lines {
+		ids[i] = l.ID()
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+func equalInt64s(a, b []int64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
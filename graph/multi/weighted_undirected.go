@@ -148,58 +148,46 @@ func (g *WeightedUndirectedGraph) Has(n graph.Node) bool {
 	return ok
 }
 
-// Nodes returns all the nodes in the graph.
+// Nodes returns all the nodes in the graph. It is a thin wrapper over
+// NodesIter provided for callers that want a snapshot slice rather than an
+// iterator.
 func (g *WeightedUndirectedGraph) Nodes() []graph.Node {
 	if len(g.nodes) == 0 {
 		return nil
 	}
-	nodes := make([]graph.Node, len(g.nodes))
-	i := 0
-	for _, n := range g.nodes {
-		nodes[i] = n
-		i++
+	nodes := make([]graph.Node, 0, len(g.nodes))
+	it := g.NodesIter()
+	for it.Next() {
+		nodes = append(nodes, it.Node())
 	}
 	return nodes
 }
 
 // Edges returns all the edges in the graph. Each edge in the returned slice
-// is a multi.Edge.
+// is a multi.Edge. It is a thin wrapper over EdgesIter provided for callers
+// that want a snapshot slice rather than an iterator.
 func (g *WeightedUndirectedGraph) Edges() []graph.Edge {
-	if len(g.lines) == 0 {
-		return nil
-	}
+	it := g.EdgesIter()
 	var edges []graph.Edge
-	seen := make(map[int64]struct{})
-	for _, u := range g.lines {
-		for _, e := range u {
-			var lines Edge
-			for _, l := range e {
-				lid := l.ID()
-				if _, ok := seen[lid]; ok {
-					continue
-				}
-				seen[lid] = struct{}{}
-				lines = append(lines, l)
-			}
-			if len(lines) != 0 {
-				edges = append(edges, lines)
-			}
-		}
+	for it.Next() {
+		edges = append(edges, it.Edge())
 	}
 	return edges
 }
 
-// From returns all nodes in g that can be reached directly from n.
+// From returns all nodes in g that can be reached directly from n. It is a
+// thin wrapper over FromIter provided for callers that want a snapshot
+// slice rather than an iterator; large multigraphs that only need to walk
+// a handful of neighbours, or stop early, should call FromIter directly to
+// avoid building the full slice.
 func (g *WeightedUndirectedGraph) From(n graph.Node) []graph.Node {
 	if !g.Has(n) {
 		return nil
 	}
-
-	nodes := make([]graph.Node, len(g.lines[n.ID()]))
-	i := 0
-	for from := range g.lines[n.ID()] {
-		nodes[i] = g.nodes[from]
-		i++
+	nodes := make([]graph.Node, 0, len(g.lines[n.ID()]))
+	it := g.FromIter(n)
+	for it.Next() {
+		nodes = append(nodes, it.Node())
 	}
 	return nodes
 }
@@ -216,21 +204,18 @@ func (g *WeightedUndirectedGraph) Lines(u, v graph.Node) []graph.Line {
 	return g.LinesBetween(u, v)
 }
 
-// LinesBetween returns the lines between nodes x and y.
+// LinesBetween returns the lines between nodes x and y. It is a thin
+// wrapper over LinesBetweenIter provided for callers that want a snapshot
+// slice rather than an iterator.
 func (g *WeightedUndirectedGraph) LinesBetween(x, y graph.Node) []graph.Line {
 	edge := g.lines[x.ID()][y.ID()]
 	if len(edge) == 0 {
 		return nil
 	}
-	var lines []graph.Line
-	seen := make(map[int64]struct{})
-	for _, l := range edge {
-		lid := l.ID()
-		if _, ok := seen[lid]; ok {
-			continue
-		}
-		seen[lid] = struct{}{}
-		lines = append(lines, l)
+	lines := make([]graph.Line, 0, len(edge))
+	it := g.LinesBetweenIter(x, y)
+	for it.Next() {
+		lines = append(lines, it.Line())
 	}
 	return lines
 }
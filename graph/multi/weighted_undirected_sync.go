@@ -0,0 +1,360 @@
+// Copyright ©2024 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package multi
+
+import (
+	"sync"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+var (
+	swug *SynchronizedWeightedUndirectedGraph
+
+	_ graph.Graph                        = swug
+	_ graph.Undirected                   = swug
+	_ graph.WeightedUndirected           = swug
+	_ graph.Multigraph                   = swug
+	_ graph.UndirectedMultigraph         = swug
+	_ graph.WeightedUndirectedMultigraph = swug
+)
+
+// SynchronizedWeightedUndirectedGraph wraps a WeightedUndirectedGraph with a
+// sync.RWMutex, making it safe for concurrent readers and writers. Each
+// exported query method takes a read lock and each mutator takes a write
+// lock of its own for the duration of the call; since sync.RWMutex is not
+// reentrant, none of these methods may be called while already holding the
+// lock returned by Locker. To batch several operations under one critical
+// section instead, take the lock returned by Locker and call methods on
+// the graph returned by Unsynchronized.
+//
+// Scope: this wraps WeightedUndirectedGraph only; the directed and
+// unweighted multigraph types this package would otherwise have siblings
+// for do not exist yet, so there is no Synchronized wrapper for them.
+type SynchronizedWeightedUndirectedGraph struct {
+	mu sync.RWMutex
+	g  *WeightedUndirectedGraph
+}
+
+// NewSynchronizedWeightedUndirectedGraph returns a
+// SynchronizedWeightedUndirectedGraph wrapping a new WeightedUndirectedGraph.
+func NewSynchronizedWeightedUndirectedGraph() *SynchronizedWeightedUndirectedGraph {
+	return &SynchronizedWeightedUndirectedGraph{g: NewWeightedUndirectedGraph()}
+}
+
+// Locker returns the mutex guarding g. It is paired with Unsynchronized to
+// let a caller batch a sequence of operations under a single critical
+// section:
+//
+//	l := g.Locker()
+//	l.Lock()
+//	defer l.Unlock()
+//	u := g.Unsynchronized()
+//	u.AddNode(n)
+//	u.SetWeighted(line)
+//
+// Calling any of SynchronizedWeightedUndirectedGraph's own locking methods
+// while holding this lock will deadlock.
+func (g *SynchronizedWeightedUndirectedGraph) Locker() *sync.RWMutex {
+	return &g.mu
+}
+
+// Unsynchronized returns the WeightedUndirectedGraph wrapped by g. Its
+// methods do no locking of their own, so it is only safe to call them
+// while holding the lock returned by Locker; see Locker's documentation.
+func (g *SynchronizedWeightedUndirectedGraph) Unsynchronized() *WeightedUndirectedGraph {
+	return g.g
+}
+
+// NewNode returns a new unique Node to be added to g.
+func (g *SynchronizedWeightedUndirectedGraph) NewNode() graph.Node {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.g.NewNode()
+}
+
+// AddNode adds n to the graph. It panics if the added node ID matches an existing node ID.
+func (g *SynchronizedWeightedUndirectedGraph) AddNode(n graph.Node) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.g.AddNode(n)
+}
+
+// RemoveNode removes n from the graph, as well as any edges attached to it.
+func (g *SynchronizedWeightedUndirectedGraph) RemoveNode(n graph.Node) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.g.RemoveNode(n)
+}
+
+// NewLine returns a new WeightedLine from the source to the destination node.
+func (g *SynchronizedWeightedUndirectedGraph) NewLine(from, to graph.Node) graph.WeightedLine {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.g.NewLine(from, to)
+}
+
+// SetWeighted adds l, a line from one node to another. If the nodes do not exist, they are added.
+func (g *SynchronizedWeightedUndirectedGraph) SetWeighted(l graph.WeightedLine) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.g.SetWeighted(l)
+}
+
+// RemoveLine removes l from the graph, leaving the terminal nodes.
+func (g *SynchronizedWeightedUndirectedGraph) RemoveLine(l graph.WeightedLine) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.g.RemoveLine(l)
+}
+
+// Node returns the node in the graph with the given ID.
+func (g *SynchronizedWeightedUndirectedGraph) Node(id int64) graph.Node {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.g.Node(id)
+}
+
+// Has returns whether the node exists within the graph.
+func (g *SynchronizedWeightedUndirectedGraph) Has(n graph.Node) bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.g.Has(n)
+}
+
+// Nodes returns all the nodes in the graph.
+func (g *SynchronizedWeightedUndirectedGraph) Nodes() []graph.Node {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.g.Nodes()
+}
+
+// Edges returns all the edges in the graph.
+func (g *SynchronizedWeightedUndirectedGraph) Edges() []graph.Edge {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.g.Edges()
+}
+
+// From returns all nodes in g that can be reached directly from n.
+func (g *SynchronizedWeightedUndirectedGraph) From(n graph.Node) []graph.Node {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.g.From(n)
+}
+
+// HasEdgeBetween returns whether an edge exists between nodes x and y.
+func (g *SynchronizedWeightedUndirectedGraph) HasEdgeBetween(x, y graph.Node) bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.g.HasEdgeBetween(x, y)
+}
+
+// Lines returns the lines from u to v if such an edge exists and nil otherwise.
+func (g *SynchronizedWeightedUndirectedGraph) Lines(u, v graph.Node) []graph.Line {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.g.Lines(u, v)
+}
+
+// LinesBetween returns the lines between nodes x and y.
+func (g *SynchronizedWeightedUndirectedGraph) LinesBetween(x, y graph.Node) []graph.Line {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.g.LinesBetween(x, y)
+}
+
+// Edge returns the edge from u to v if such an edge exists and nil otherwise.
+func (g *SynchronizedWeightedUndirectedGraph) Edge(u, v graph.Node) graph.Edge {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.g.Edge(u, v)
+}
+
+// EdgeBetween returns the edge between nodes x and y.
+func (g *SynchronizedWeightedUndirectedGraph) EdgeBetween(x, y graph.Node) graph.Edge {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.g.EdgeBetween(x, y)
+}
+
+// WeightedEdge returns the weighted edge from u to v if such an edge exists and nil otherwise.
+func (g *SynchronizedWeightedUndirectedGraph) WeightedEdge(u, v graph.Node) graph.WeightedEdge {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.g.WeightedEdge(u, v)
+}
+
+// WeightedEdgeBetween returns the weighted edge between nodes x and y.
+func (g *SynchronizedWeightedUndirectedGraph) WeightedEdgeBetween(x, y graph.Node) graph.WeightedEdge {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.g.WeightedEdgeBetween(x, y)
+}
+
+// WeightedLines returns the lines from u to v if such an edge exists and nil otherwise.
+func (g *SynchronizedWeightedUndirectedGraph) WeightedLines(u, v graph.Node) []graph.WeightedLine {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.g.WeightedLines(u, v)
+}
+
+// WeightedLinesBetween returns the lines between nodes x and y.
+func (g *SynchronizedWeightedUndirectedGraph) WeightedLinesBetween(x, y graph.Node) []graph.WeightedLine {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.g.WeightedLinesBetween(x, y)
+}
+
+// Weight returns the weight for the lines between x and y summarised by the
+// underlying graph's EdgeWeightFunc. Weight returns true if an edge exists
+// between x and y, false otherwise.
+func (g *SynchronizedWeightedUndirectedGraph) Weight(x, y graph.Node) (w float64, ok bool) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.g.Weight(x, y)
+}
+
+// Degree returns the degree of n in g.
+func (g *SynchronizedWeightedUndirectedGraph) Degree(n graph.Node) int {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.g.Degree(n)
+}
+
+// snapshotNodeIter, snapshotEdgeIter and snapshotLineIter back the
+// synchronized iterator methods below. WeightedUndirectedGraph's own
+// NodesIter, EdgesIter, FromIter and LinesBetweenIter walk its maps lazily,
+// one entry per call to Next; a Next call that ran after Locker's read lock
+// had already been released would read those maps while a writer could be
+// mutating them, which is exactly the race this type exists to prevent. So,
+// unlike the unsynchronized graph, these copy their result while still
+// holding the read lock and iterate over that fixed copy afterwards.
+type snapshotNodeIter struct {
+	nodes []graph.Node
+	pos   int
+}
+
+func (it *snapshotNodeIter) Next() bool {
+	if it.pos >= len(it.nodes)-1 {
+		it.pos = len(it.nodes)
+		return false
+	}
+	it.pos++
+	return true
+}
+
+func (it *snapshotNodeIter) Node() graph.Node {
+	if it.pos < 0 || it.pos >= len(it.nodes) {
+		return nil
+	}
+	return it.nodes[it.pos]
+}
+
+type snapshotEdgeIter struct {
+	edges []graph.Edge
+	pos   int
+}
+
+func (it *snapshotEdgeIter) Next() bool {
+	if it.pos >= len(it.edges)-1 {
+		it.pos = len(it.edges)
+		return false
+	}
+	it.pos++
+	return true
+}
+
+func (it *snapshotEdgeIter) Edge() graph.Edge {
+	if it.pos < 0 || it.pos >= len(it.edges) {
+		return nil
+	}
+	return it.edges[it.pos]
+}
+
+type snapshotLineIter struct {
+	lines []graph.Line
+	pos   int
+}
+
+func (it *snapshotLineIter) Next() bool {
+	if it.pos >= len(it.lines)-1 {
+		it.pos = len(it.lines)
+		return false
+	}
+	it.pos++
+	return true
+}
+
+func (it *snapshotLineIter) Line() graph.Line {
+	if it.pos < 0 || it.pos >= len(it.lines) {
+		return nil
+	}
+	return it.lines[it.pos]
+}
+
+// NodesIter returns an iterator over a snapshot of g's nodes taken under a
+// read lock.
+func (g *SynchronizedWeightedUndirectedGraph) NodesIter() NodeIter {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return &snapshotNodeIter{nodes: g.g.Nodes(), pos: -1}
+}
+
+// EdgesIter returns an iterator over a snapshot of g's edges taken under a
+// read lock.
+func (g *SynchronizedWeightedUndirectedGraph) EdgesIter() EdgeIter {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return &snapshotEdgeIter{edges: g.g.Edges(), pos: -1}
+}
+
+// FromIter returns an iterator over a snapshot, taken under a read lock, of
+// the nodes that can be reached directly from n.
+func (g *SynchronizedWeightedUndirectedGraph) FromIter(n graph.Node) NodeIter {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return &snapshotNodeIter{nodes: g.g.From(n), pos: -1}
+}
+
+// LinesBetweenIter returns an iterator over a snapshot, taken under a read
+// lock, of the lines between nodes x and y.
+func (g *SynchronizedWeightedUndirectedGraph) LinesBetweenIter(x, y graph.Node) LineIter {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return &snapshotLineIter{lines: g.g.LinesBetween(x, y), pos: -1}
+}
+
+// View returns an immutable snapshot of g's current state, suitable for
+// passing to read-only algorithms such as those in
+// gonum.org/v1/gonum/graph/traverse without holding g's lock across a
+// long-running traversal. The snapshot is unaffected by later mutations of g.
+func (g *SynchronizedWeightedUndirectedGraph) View() graph.WeightedUndirectedMultigraph {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	snap := NewWeightedUndirectedGraph()
+	snap.EdgeWeightFunc = g.g.EdgeWeightFunc
+
+	nodes := g.g.Nodes()
+	for _, n := range nodes {
+		snap.AddNode(n)
+	}
+
+	seen := make(map[int64]bool)
+	for _, u := range nodes {
+		for _, v := range g.g.From(u) {
+			for _, l := range g.g.WeightedLines(u, v) {
+				if seen[l.ID()] {
+					continue
+				}
+				seen[l.ID()] = true
+				snap.SetWeighted(l)
+			}
+		}
+	}
+
+	return snap
+}
@@ -0,0 +1,97 @@
+// Copyright ©2024 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package multi
+
+import (
+	"sync"
+	"testing"
+)
+
+// TestSynchronizedConcurrentAccess exercises concurrent writers and readers
+// against a SynchronizedWeightedUndirectedGraph. It is only able to detect
+// data races when run with the race detector (go test -race), but it
+// should not panic or deadlock either way.
+//
+// All n nodes are added up front, before any goroutine runs, rather than
+// from within the writer goroutines: SetWeighted auto-adds its line's
+// endpoints if they are missing, so a goroutine calling AddNode(i)
+// concurrently with another goroutine's SetWeighted of a line touching the
+// same id races two different paths to the same AddNode call and panics on
+// the resulting duplicate-ID check, regardless of the lock.
+func TestSynchronizedConcurrentAccess(t *testing.T) {
+	g := NewSynchronizedWeightedUndirectedGraph()
+
+	const n = 50
+	for i := int64(0); i < n; i++ {
+		g.AddNode(testNode(i))
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(2 * n)
+	for i := 0; i < n; i++ {
+		i := int64(i)
+		go func() {
+			defer wg.Done()
+			if i > 0 {
+				g.SetWeighted(testLine{id: i, f: testNode(i - 1), t: testNode(i), weight: float64(i)})
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			_ = g.Nodes()
+			_ = g.Edges()
+			_ = g.Degree(testNode(i))
+		}()
+	}
+	wg.Wait()
+
+	if got := len(g.Nodes()); got != n {
+		t.Errorf("got %d nodes after concurrent inserts, want %d", got, n)
+	}
+}
+
+func TestSynchronizedViewIsolation(t *testing.T) {
+	g := NewSynchronizedWeightedUndirectedGraph()
+	g.AddNode(testNode(1))
+	g.AddNode(testNode(2))
+	g.SetWeighted(testLine{id: 1, f: testNode(1), t: testNode(2), weight: 1})
+
+	view := g.View()
+	wantNodes := len(view.Nodes())
+	wantEdges := len(view.Edges())
+
+	g.AddNode(testNode(3))
+	g.SetWeighted(testLine{id: 2, f: testNode(2), t: testNode(3), weight: 2})
+
+	if got := len(view.Nodes()); got != wantNodes {
+		t.Errorf("View node count changed after mutating g: got %d, want %d", got, wantNodes)
+	}
+	if got := len(view.Edges()); got != wantEdges {
+		t.Errorf("View edge count changed after mutating g: got %d, want %d", got, wantEdges)
+	}
+}
+
+// TestSynchronizedLockerUnsynchronized exercises the documented way to
+// batch several operations under one critical section: take Locker, then
+// call methods on Unsynchronized rather than on g itself, which would
+// deadlock against the non-reentrant mutex.
+func TestSynchronizedLockerUnsynchronized(t *testing.T) {
+	g := NewSynchronizedWeightedUndirectedGraph()
+
+	l := g.Locker()
+	l.Lock()
+	u := g.Unsynchronized()
+	u.AddNode(testNode(1))
+	u.AddNode(testNode(2))
+	u.SetWeighted(testLine{id: 1, f: testNode(1), t: testNode(2), weight: 1})
+	l.Unlock()
+
+	if got := len(g.Nodes()); got != 2 {
+		t.Errorf("got %d nodes after batched update, want 2", got)
+	}
+	if got := len(g.Edges()); got != 1 {
+		t.Errorf("got %d edges after batched update, want 1", got)
+	}
+}
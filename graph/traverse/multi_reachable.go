@@ -0,0 +1,24 @@
+// Copyright ©2024 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package traverse
+
+import "gonum.org/v1/gonum/graph"
+
+// ReachableFromMulti returns the set, keyed by node ID, of nodes reachable
+// from n in g. It is a thin wrapper over BreadthFirst.Walk, which takes g's
+// FromIter fast path automatically when g is a type like
+// multi.WeightedUndirectedGraph, so no intermediate []graph.Node is built
+// for the neighbours of each node visited.
+// g need not be a multigraph: ReachableFromMulti works for any graph.Graph,
+// falling back to From where FromIter isn't available.
+func ReachableFromMulti(g graph.Graph, n graph.Node) map[int64]bool {
+	seen := make(map[int64]bool)
+	var b BreadthFirst
+	b.Walk(g, n, func(v graph.Node) bool {
+		seen[v.ID()] = true
+		return false
+	})
+	return seen
+}
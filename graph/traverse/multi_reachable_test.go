@@ -0,0 +1,74 @@
+// Copyright ©2024 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package traverse
+
+import (
+	"sort"
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/multi"
+)
+
+type reachableTestNode int64
+
+func (n reachableTestNode) ID() int64 { return int64(n) }
+
+type reachableTestLine struct {
+	id     int64
+	f, t   graph.Node
+	weight float64
+}
+
+func (l reachableTestLine) ID() int64        { return l.id }
+func (l reachableTestLine) From() graph.Node { return l.f }
+func (l reachableTestLine) To() graph.Node   { return l.t }
+func (l reachableTestLine) Weight() float64  { return l.weight }
+func (l reachableTestLine) ReversedLine() graph.Line {
+	return reachableTestLine{id: l.id, f: l.t, t: l.f, weight: l.weight}
+}
+
+// reachableTestGraph builds a graph with parallel edges between nodes 1
+// and 2, and a disconnected component {4, 5} unreachable from node 1.
+func reachableTestGraph() *multi.WeightedUndirectedGraph {
+	g := multi.NewWeightedUndirectedGraph()
+	for _, id := range []int64{1, 2, 3, 4, 5} {
+		g.AddNode(reachableTestNode(id))
+	}
+	g.SetWeighted(reachableTestLine{id: 10, f: reachableTestNode(1), t: reachableTestNode(2), weight: 0.5})
+	g.SetWeighted(reachableTestLine{id: 11, f: reachableTestNode(1), t: reachableTestNode(2), weight: 1.5})
+	g.SetWeighted(reachableTestLine{id: 12, f: reachableTestNode(2), t: reachableTestNode(3), weight: 2})
+	g.SetWeighted(reachableTestLine{id: 13, f: reachableTestNode(4), t: reachableTestNode(5), weight: 1})
+	return g
+}
+
+func TestReachableFromMulti(t *testing.T) {
+	g := reachableTestGraph()
+
+	got := ReachableFromMulti(g, reachableTestNode(1))
+
+	var gotIDs []int64
+	for id, ok := range got {
+		if ok {
+			gotIDs = append(gotIDs, id)
+		}
+	}
+	sort.Slice(gotIDs, func(i, j int) bool { return gotIDs[i] < gotIDs[j] })
+
+	want := []int64{1, 2, 3}
+	if len(gotIDs) != len(want) {
+		t.Fatalf("ReachableFromMulti returned %v, want %v", gotIDs, want)
+	}
+	for i, id := range want {
+		if gotIDs[i] != id {
+			t.Errorf("ReachableFromMulti returned %v, want %v", gotIDs, want)
+			break
+		}
+	}
+
+	if got[4] || got[5] {
+		t.Errorf("ReachableFromMulti marked disconnected nodes 4 or 5 as reachable: %v", got)
+	}
+}
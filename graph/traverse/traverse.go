@@ -0,0 +1,121 @@
+// Copyright ©2024 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package traverse
+
+import (
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/multi"
+)
+
+// nodeIter is the common shape of multi.NodeIter, named locally so this
+// file does not need any exported iterator type of its own.
+type nodeIter interface {
+	Next() bool
+	Node() graph.Node
+}
+
+// fromIterer is satisfied by a graph that can report a node's neighbours
+// one at a time, such as multi.WeightedUndirectedGraph. BreadthFirst
+// prefers it over graph.Graph's From, which must return the whole
+// []graph.Node neighbour set up front, so that allocation would otherwise
+// be paid at every step of the walk.
+type fromIterer interface {
+	FromIter(graph.Node) multi.NodeIter
+}
+
+// sliceNodeIter adapts a []graph.Node, as returned by graph.Graph's From,
+// to nodeIter, so BreadthFirst has a single walking loop regardless of
+// which form of neighbour access g supports.
+type sliceNodeIter struct {
+	nodes []graph.Node
+	pos   int
+}
+
+func (it *sliceNodeIter) Next() bool {
+	if it.pos >= len(it.nodes)-1 {
+		it.pos = len(it.nodes)
+		return false
+	}
+	it.pos++
+	return true
+}
+
+func (it *sliceNodeIter) Node() graph.Node {
+	if it.pos < 0 || it.pos >= len(it.nodes) {
+		return nil
+	}
+	return it.nodes[it.pos]
+}
+
+// neighborsOf returns an iterator over the nodes directly reachable from n
+// in g, taking g's FromIter fast path when g implements fromIterer.
+func neighborsOf(g graph.Graph, n graph.Node) nodeIter {
+	if fi, ok := g.(fromIterer); ok {
+		return fi.FromIter(n)
+	}
+	return &sliceNodeIter{nodes: g.From(n), pos: -1}
+}
+
+// BreadthFirst implements stateful breadth-first graph traversal.
+type BreadthFirst struct {
+	visited map[int64]bool
+}
+
+// Walk walks g in breadth-first order starting from n, calling visit once
+// for each node the first time Walk reaches it, including n itself. If
+// visit returns true, Walk stops early and returns the node it was called
+// with; otherwise Walk returns nil once the whole component containing n
+// has been visited. Calling Walk again on the same BreadthFirst continues
+// to treat previously visited nodes as visited; call Reset first to start
+// a fresh walk.
+//
+// Walk fetches each visited node's neighbours through neighborsOf, which
+// uses g's FromIter method in preference to From when g implements
+// fromIterer, so walking a large multigraph does not allocate a neighbour
+// slice at every step.
+func (b *BreadthFirst) Walk(g graph.Graph, n graph.Node, visit func(graph.Node) bool) graph.Node {
+	if b.visited == nil {
+		b.visited = make(map[int64]bool)
+	}
+	if b.visited[n.ID()] {
+		return nil
+	}
+	b.visited[n.ID()] = true
+	if visit != nil && visit(n) {
+		return n
+	}
+
+	queue := []graph.Node{n}
+	for len(queue) > 0 {
+		u := queue[0]
+		queue = queue[1:]
+
+		it := neighborsOf(g, u)
+		for it.Next() {
+			v := it.Node()
+			if b.visited[v.ID()] {
+				continue
+			}
+			b.visited[v.ID()] = true
+			if visit != nil && visit(v) {
+				return v
+			}
+			queue = append(queue, v)
+		}
+	}
+	return nil
+}
+
+// Visited reports whether n has been reached by any call to Walk since b
+// was created or last Reset.
+func (b *BreadthFirst) Visited(n graph.Node) bool {
+	return b.visited[n.ID()]
+}
+
+// Reset discards b's set of visited nodes, so it can be reused for a new
+// call to Walk.
+func (b *BreadthFirst) Reset() {
+	b.visited = nil
+}
@@ -0,0 +1,94 @@
+// Copyright ©2024 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package traverse
+
+import (
+	"sort"
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+type traverseTestNode int64
+
+func (n traverseTestNode) ID() int64 { return int64(n) }
+
+// plainGraph is a minimal graph.Graph that does not implement fromIterer,
+// used to exercise BreadthFirst's From-based fallback path.
+type plainGraph struct {
+	adj map[int64][]graph.Node
+}
+
+func (g *plainGraph) Node(id int64) graph.Node { return traverseTestNode(id) }
+
+func (g *plainGraph) Nodes() []graph.Node {
+	nodes := make([]graph.Node, 0, len(g.adj))
+	for id := range g.adj {
+		nodes = append(nodes, traverseTestNode(id))
+	}
+	return nodes
+}
+
+func (g *plainGraph) From(n graph.Node) []graph.Node { return g.adj[n.ID()] }
+
+func (g *plainGraph) HasEdgeBetween(x, y graph.Node) bool {
+	for _, v := range g.adj[x.ID()] {
+		if v.ID() == y.ID() {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *plainGraph) Edge(u, v graph.Node) graph.Edge { return nil }
+
+func TestBreadthFirstWalkFallback(t *testing.T) {
+	g := &plainGraph{adj: map[int64][]graph.Node{
+		1: {traverseTestNode(2)},
+		2: {traverseTestNode(1), traverseTestNode(3)},
+		3: {traverseTestNode(2)},
+		4: {traverseTestNode(5)},
+		5: {traverseTestNode(4)},
+	}}
+
+	var b BreadthFirst
+	var got []int64
+	b.Walk(g, traverseTestNode(1), func(n graph.Node) bool {
+		got = append(got, n.ID())
+		return false
+	})
+	sort.Slice(got, func(i, j int) bool { return got[i] < got[j] })
+
+	want := []int64{1, 2, 3}
+	if len(got) != len(want) {
+		t.Fatalf("Walk visited %v, want %v", got, want)
+	}
+	for i, id := range want {
+		if got[i] != id {
+			t.Errorf("Walk visited %v, want %v", got, want)
+			break
+		}
+	}
+
+	if b.Visited(traverseTestNode(4)) || b.Visited(traverseTestNode(5)) {
+		t.Error("Walk marked a disconnected component as visited")
+	}
+}
+
+func TestBreadthFirstWalkEarlyStop(t *testing.T) {
+	g := &plainGraph{adj: map[int64][]graph.Node{
+		1: {traverseTestNode(2)},
+		2: {traverseTestNode(1), traverseTestNode(3)},
+		3: {traverseTestNode(2)},
+	}}
+
+	var b BreadthFirst
+	found := b.Walk(g, traverseTestNode(1), func(n graph.Node) bool {
+		return n.ID() == 3
+	})
+	if found == nil || found.ID() != 3 {
+		t.Fatalf("Walk returned %v, want node 3", found)
+	}
+}